@@ -0,0 +1,55 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPath2BucketObject(t *testing.T) {
+	testCases := []struct {
+		path           string
+		expectedBucket string
+		expectedObject string
+	}{
+		{"", "", ""},
+		{"/", "", ""},
+		{"/bucket", "bucket", ""},
+		{"/bucket/", "bucket", ""},
+		{"/bucket/object", "bucket", "object"},
+		{"/bucket/with/slashes/object", "bucket", "with/slashes/object"},
+	}
+
+	for _, testCase := range testCases {
+		bucket, object := path2BucketObject(testCase.path)
+		if bucket != testCase.expectedBucket || object != testCase.expectedObject {
+			t.Errorf("path2BucketObject(%q): got (%q, %q), want (%q, %q)",
+				testCase.path, bucket, object, testCase.expectedBucket, testCase.expectedObject)
+		}
+	}
+}
+
+func TestWithContextNoFields(t *testing.T) {
+	cl := log.WithContext(context.Background())
+	if cl == nil {
+		t.Fatal("expected a non-nil ContextLogger")
+	}
+	if len(cl.fields) != 0 {
+		t.Errorf("expected no fields for a context carrying none, got %v", cl.fields)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults applied when MINIO_LOGGER_FILE_MAX_SIZE/MAX_AGE are not set.
+const (
+	defaultFileSinkMaxSize = 100 * 1024 * 1024 // 100MiB
+	defaultFileSinkMaxAge  = 7 * 24 * time.Hour // 7 days
+)
+
+// fileSink writes JSON logEntry records, one per line, to a local file.
+// A non-empty file is rotated before a write that would take it past
+// maxSize; rotated segments are
+// gzip-compressed, and segments older than maxAge are removed on the
+// next rotation.
+type fileSink struct {
+	level   Level
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newFileSink(path string, level Level) (*fileSink, error) {
+	sink := &fileSink{
+		level:   level,
+		path:    path,
+		maxSize: envBytesOrDefault(envLoggerFileMaxSize, defaultFileSinkMaxSize),
+		maxAge:  envDurationOrDefault(envLoggerFileMaxAge, defaultFileSinkMaxAge),
+	}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (f *fileSink) Level() Level {
+	return f.level
+}
+
+func (f *fileSink) Send(entry logEntry) error {
+	buf, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(buf)) > f.maxSize {
+		if err = f.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(buf)
+	f.size += int64(n)
+	return err
+}
+
+// open opens (creating if needed) the sink's current log file for
+// appending and records its existing size.
+func (f *fileSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = fi.Size()
+	return nil
+}
+
+// rotate closes the current file, gzips it aside under a timestamped
+// name, prunes segments past maxAge, and opens a fresh file in its place.
+// Caller must hold f.mu.
+func (f *fileSink) rotate() error {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+
+	if _, err := os.Stat(f.path); err == nil {
+		rotated := fmt.Sprintf("%s.%s.gz", f.path, UTCNow().Format("20060102150405.000000000"))
+		if err := gzipFile(f.path, rotated); err != nil {
+			return err
+		}
+		if err := os.Remove(f.path); err != nil {
+			return err
+		}
+	}
+
+	if err := pruneOldSegments(f.path, f.maxAge); err != nil {
+		errorIf(context.Background(), err, "Unable to prune rotated log segments for %s", f.path)
+	}
+
+	return f.open()
+}
+
+// gzipFile compresses src into dst, leaving src untouched for the caller
+// to remove once the copy has succeeded.
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err = io.Copy(gz, in); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// pruneOldSegments removes rotated "<path>.<timestamp>.gz" segments whose
+// file modification time is older than maxAge.
+func pruneOldSegments(path string, maxAge time.Duration) error {
+	dir := filepath.Dir(path)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+
+	base := filepath.Base(path)
+	cutoff := UTCNow().Add(-maxAge)
+	for _, fi := range entries {
+		name := fi.Name()
+		if !strings.HasPrefix(name, base+".") || !strings.HasSuffix(name, ".gz") {
+			continue
+		}
+		if fi.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGzipFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger-sink-file-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src.log")
+	want := []byte("line one\nline two\n")
+	if err = ioutil.WriteFile(src, want, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "dst.log.gz")
+	if err = gzipFile(src, dst); err != nil {
+		t.Fatalf("gzipFile: %v", err)
+	}
+
+	// src is left untouched by gzipFile; removing it is the caller's job.
+	if _, err = os.Stat(src); err != nil {
+		t.Fatalf("expected src to still exist: %v", err)
+	}
+
+	f, err := os.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("dst is not valid gzip: %v", err)
+	}
+	defer gz.Close()
+
+	got, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("gzipFile roundtrip: got %q, want %q", got, want)
+	}
+}
+
+func TestPruneOldSegments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger-sink-file-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "minio.log")
+	oldSegment := path + ".old.gz"
+	freshSegment := path + ".fresh.gz"
+	unrelated := filepath.Join(dir, "unrelated.gz")
+
+	for _, name := range []string{oldSegment, freshSegment, unrelated} {
+		if err = ioutil.WriteFile(name, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	if err = os.Chtimes(oldSegment, now.Add(-48*time.Hour), now.Add(-48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(freshSegment, now, now); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(unrelated, now.Add(-48*time.Hour), now.Add(-48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = pruneOldSegments(path, 24*time.Hour); err != nil {
+		t.Fatalf("pruneOldSegments: %v", err)
+	}
+
+	if _, err = os.Stat(oldSegment); !os.IsNotExist(err) {
+		t.Errorf("expected old segment to be pruned, stat err = %v", err)
+	}
+	if _, err = os.Stat(freshSegment); err != nil {
+		t.Errorf("expected fresh segment to survive: %v", err)
+	}
+	if _, err = os.Stat(unrelated); err != nil {
+		t.Errorf("expected unrelated file outside the path prefix to survive: %v", err)
+	}
+}
+
+func TestFileSinkRotatesOnSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger-sink-file-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "minio.log")
+	sink := &fileSink{
+		level:   Info,
+		path:    path,
+		maxSize: 10,
+		maxAge:  time.Hour,
+	}
+	if err = sink.open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each entry serializes to well over maxSize, so every Send after the
+	// first should trigger a rotation of the previous segment.
+	for i := 0; i < 3; i++ {
+		if err = sink.Send(logEntry{Level: "INFO", Message: "hello world"}); err != nil {
+			t.Fatalf("Send #%d: %v", i, err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated, gzipped segment after exceeding maxSize")
+	}
+	if _, err = os.Stat(path); err != nil {
+		t.Errorf("expected a fresh current log file to exist: %v", err)
+	}
+}
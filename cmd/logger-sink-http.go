@@ -0,0 +1,100 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Tuning for the HTTP webhook sink's retry/backoff. A failing endpoint
+// is retried with exponential backoff before the entry is given up on.
+const (
+	httpSinkQueueSize  = 10000
+	httpSinkMaxRetries = 5
+	httpSinkMinBackoff = 500 * time.Millisecond
+	httpSinkMaxBackoff = 30 * time.Second
+)
+
+// httpSink POSTs the JSON logEntry to a configured webhook endpoint. A
+// bounded queue and a dedicated delivery goroutine keep a slow or down
+// endpoint from blocking the caller of Send.
+type httpSink struct {
+	level    Level
+	endpoint string
+	client   *http.Client
+	queue    chan logEntry
+}
+
+func newHTTPSink(endpoint string, level Level) *httpSink {
+	sink := &httpSink{
+		level:    level,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		queue:    make(chan logEntry, httpSinkQueueSize),
+	}
+	go sink.loop()
+	return sink
+}
+
+func (h *httpSink) Level() Level {
+	return h.level
+}
+
+func (h *httpSink) Send(entry logEntry) error {
+	select {
+	case h.queue <- entry:
+		return nil
+	default:
+		return fmt.Errorf("http log sink buffer full, dropping entry for %s", h.endpoint)
+	}
+}
+
+func (h *httpSink) loop() {
+	for entry := range h.queue {
+		h.post(entry)
+	}
+}
+
+// post delivers entry to the webhook, retrying with exponential backoff
+// on failure or a 5xx response before giving up.
+func (h *httpSink) post(entry logEntry) {
+	body, err := json.Marshal(&entry)
+	if err != nil {
+		return
+	}
+
+	backoff := httpSinkMinBackoff
+	for attempt := 0; attempt <= httpSinkMaxRetries; attempt++ {
+		resp, err := h.client.Post(h.endpoint, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return
+			}
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > httpSinkMaxBackoff {
+			backoff = httpSinkMaxBackoff
+		}
+	}
+}
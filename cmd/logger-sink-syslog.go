@@ -0,0 +1,143 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// syslogFacilityLocal0 is the RFC 5424 facility minio logs under.
+const syslogFacilityLocal0 = 16
+
+// syslogDialTimeout bounds how long dial() waits to establish the
+// connection, and syslogWriteTimeout bounds each Send's write, so an
+// unreachable or firewalled endpoint can't stall the single worker
+// goroutine that drains Logger.entries (or a synchronous fatalIf).
+const (
+	syslogDialTimeout  = 5 * time.Second
+	syslogWriteTimeout = 5 * time.Second
+)
+
+// syslogSink forwards logEntry records as RFC 5424 syslog messages over
+// UDP, TCP, or TLS, depending on the scheme of its configured endpoint
+// (e.g. "udp://host:514", "tcp://host:514", "tls://host:6514").
+type syslogSink struct {
+	level    Level
+	network  string
+	addr     string
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(endpoint string, level Level) (*syslogSink, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unsupported syslog scheme %q, expected udp, tcp or tls", u.Scheme)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &syslogSink{
+		level:    level,
+		network:  u.Scheme,
+		addr:     u.Host,
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslogSink) Level() Level {
+	return s.level
+}
+
+func (s *syslogSink) Send(entry logEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout)); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	if _, err := s.conn.Write([]byte(formatRFC5424(entry, s.hostname))); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *syslogSink) dial() (net.Conn, error) {
+	switch s.network {
+	case "tls":
+		dialer := &net.Dialer{Timeout: syslogDialTimeout}
+		return tls.DialWithDialer(dialer, "tcp", s.addr, &tls.Config{})
+	default:
+		return net.DialTimeout(s.network, s.addr, syslogDialTimeout)
+	}
+}
+
+// formatRFC5424 renders entry as an RFC 5424 syslog message.
+func formatRFC5424(entry logEntry, hostname string) string {
+	return fmt.Sprintf("<%d>1 %s %s minio - - - %s\n",
+		syslogPriority(entry.Level), entry.Time, hostname, entry.Message)
+}
+
+// syslogPriority computes the RFC 5424 PRI value (facility*8 + severity)
+// for a logEntry's Level.
+func syslogPriority(level string) int {
+	var severity int
+	switch levelFromString(level) {
+	case Fatal:
+		severity = 2 // Critical
+	case Error:
+		severity = 3
+	case Warn:
+		severity = 4
+	case Info:
+		severity = 6
+	case Debug:
+		severity = 7
+	default:
+		severity = 6
+	}
+	return syslogFacilityLocal0*8 + severity
+}
@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSyslogPriority(t *testing.T) {
+	testCases := []struct {
+		level    string
+		expected int
+	}{
+		{"FATAL", syslogFacilityLocal0*8 + 2},
+		{"ERROR", syslogFacilityLocal0*8 + 3},
+		{"WARN", syslogFacilityLocal0*8 + 4},
+		{"INFO", syslogFacilityLocal0*8 + 6},
+		{"DEBUG", syslogFacilityLocal0*8 + 7},
+		{"BOGUS", syslogFacilityLocal0*8 + 3}, // levelFromString falls back to Error for an unrecognized level
+	}
+	for _, testCase := range testCases {
+		if p := syslogPriority(testCase.level); p != testCase.expected {
+			t.Errorf("syslogPriority(%q): got %d, want %d", testCase.level, p, testCase.expected)
+		}
+	}
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	entry := logEntry{
+		Level:   "ERROR",
+		Message: "something failed",
+		Time:    "2017-01-01T00:00:00Z",
+	}
+	msg := formatRFC5424(entry, "test-host")
+
+	wantPrefix := "<" + strconv.Itoa(syslogFacilityLocal0*8+3) + ">1 2017-01-01T00:00:00Z test-host minio - - - something failed"
+	if !strings.HasPrefix(msg, wantPrefix) {
+		t.Errorf("formatRFC5424: got %q, want prefix %q", msg, wantPrefix)
+	}
+	if !strings.HasSuffix(msg, "\n") {
+		t.Errorf("formatRFC5424: expected trailing newline, got %q", msg)
+	}
+}
+
+func TestNewSyslogSinkRejectsUnsupportedScheme(t *testing.T) {
+	if _, err := newSyslogSink("http://localhost:514", Info); err == nil {
+		t.Fatal("expected an error for an unsupported syslog scheme, got nil")
+	}
+}
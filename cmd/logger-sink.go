@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LogSink receives every logEntry dispatched by logIf that meets its
+// minimum Level. Implementations must not block the caller of Send for
+// longer than it takes to hand the entry off to their own delivery path
+// (a queue, a background connection, ...).
+type LogSink interface {
+	// Send delivers entry to the sink's destination. A returned error is
+	// logged to the console but otherwise does not stop dispatch to
+	// other sinks.
+	Send(entry logEntry) error
+
+	// Level returns the minimum severity this sink accepts; entries
+	// below it are skipped without calling Send.
+	Level() Level
+}
+
+// Environment variables used to configure the built-in sinks. Each sink
+// is independently optional: it is only registered when its endpoint/path
+// variable is set.
+const (
+	envLoggerFileLevel   = "MINIO_LOGGER_FILE_LEVEL"
+	envLoggerFilePath    = "MINIO_LOGGER_FILE_PATH"
+	envLoggerFileMaxSize = "MINIO_LOGGER_FILE_MAX_SIZE"
+	envLoggerFileMaxAge  = "MINIO_LOGGER_FILE_MAX_AGE"
+
+	envLoggerSyslogLevel    = "MINIO_LOGGER_SYSLOG_LEVEL"
+	envLoggerSyslogEndpoint = "MINIO_LOGGER_SYSLOG_ENDPOINT"
+
+	envLoggerHTTPLevel    = "MINIO_LOGGER_HTTP_LEVEL"
+	envLoggerHTTPEndpoint = "MINIO_LOGGER_HTTP_ENDPOINT"
+)
+
+func init() {
+	registerConfiguredLogSinks(log)
+}
+
+// registerConfiguredLogSinks inspects the environment and registers the
+// built-in file, syslog and HTTP sinks that have been configured, each at
+// its own minimum Level (defaulting to Info when unset).
+func registerConfiguredLogSinks(log *Logger) {
+	if path := os.Getenv(envLoggerFilePath); path != "" {
+		sink, err := newFileSink(path, envLevelOrDefault(envLoggerFileLevel, Info))
+		if err != nil {
+			errorIf(context.Background(), err, "Unable to initialize file log sink at %s", path)
+		} else {
+			log.AddSink(sink)
+		}
+	}
+
+	if endpoint := os.Getenv(envLoggerSyslogEndpoint); endpoint != "" {
+		sink, err := newSyslogSink(endpoint, envLevelOrDefault(envLoggerSyslogLevel, Info))
+		if err != nil {
+			errorIf(context.Background(), err, "Unable to initialize syslog log sink at %s", endpoint)
+		} else {
+			log.AddSink(sink)
+		}
+	}
+
+	if endpoint := os.Getenv(envLoggerHTTPEndpoint); endpoint != "" {
+		log.AddSink(newHTTPSink(endpoint, envLevelOrDefault(envLoggerHTTPLevel, Info)))
+	}
+}
+
+// envLevelOrDefault reads a Level by name (e.g. "WARN") from the named
+// environment variable, falling back to def when unset or unrecognized.
+func envLevelOrDefault(envName string, def Level) Level {
+	v := os.Getenv(envName)
+	if v == "" {
+		return def
+	}
+	level, ok := parseLevel(v)
+	if !ok {
+		return def
+	}
+	return level
+}
+
+// envDurationOrDefault reads a time.Duration expressed in whole hours
+// from the named environment variable, falling back to def when unset
+// or invalid.
+func envDurationOrDefault(envName string, def time.Duration) time.Duration {
+	v := os.Getenv(envName)
+	if v == "" {
+		return def
+	}
+	hours, err := strconv.Atoi(v)
+	if err != nil || hours <= 0 {
+		return def
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// envBytesOrDefault reads a size expressed in mebibytes from the named
+// environment variable, falling back to def when unset or invalid.
+func envBytesOrDefault(envName string, def int64) int64 {
+	v := os.Getenv(envName)
+	if v == "" {
+		return def
+	}
+	mb, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || mb <= 0 {
+		return def
+	}
+	return mb * 1024 * 1024
+}
@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEnvLevelOrDefault(t *testing.T) {
+	const envName = "MINIO_LOGGER_TEST_LEVEL"
+	defer os.Unsetenv(envName)
+
+	testCases := []struct {
+		value    string
+		def      Level
+		expected Level
+	}{
+		{"", Info, Info},
+		{"WARN", Info, Warn},
+		{"INFOO", Info, Info}, // unrecognized value falls back to def, not Error
+		{"error", Info, Info}, // case mismatch is also unrecognized
+	}
+
+	for _, testCase := range testCases {
+		if testCase.value == "" {
+			os.Unsetenv(envName)
+		} else {
+			os.Setenv(envName, testCase.value)
+		}
+		if level := envLevelOrDefault(envName, testCase.def); level != testCase.expected {
+			t.Errorf("envLevelOrDefault(%q, def=%v): got %v, want %v",
+				testCase.value, testCase.def, level, testCase.expected)
+		}
+	}
+}
+
+func TestEnvDurationOrDefault(t *testing.T) {
+	const envName = "MINIO_LOGGER_TEST_MAX_AGE"
+	defer os.Unsetenv(envName)
+
+	testCases := []struct {
+		value    string
+		def      time.Duration
+		expected time.Duration
+	}{
+		{"", time.Hour, time.Hour},
+		{"24", time.Hour, 24 * time.Hour},
+		{"0", time.Hour, time.Hour},
+		{"-1", time.Hour, time.Hour},
+		{"not-a-number", time.Hour, time.Hour},
+	}
+
+	for _, testCase := range testCases {
+		if testCase.value == "" {
+			os.Unsetenv(envName)
+		} else {
+			os.Setenv(envName, testCase.value)
+		}
+		if d := envDurationOrDefault(envName, testCase.def); d != testCase.expected {
+			t.Errorf("envDurationOrDefault(%q, def=%v): got %v, want %v",
+				testCase.value, testCase.def, d, testCase.expected)
+		}
+	}
+}
+
+func TestEnvBytesOrDefault(t *testing.T) {
+	const envName = "MINIO_LOGGER_TEST_MAX_SIZE"
+	defer os.Unsetenv(envName)
+
+	testCases := []struct {
+		value    string
+		def      int64
+		expected int64
+	}{
+		{"", 10 * 1024 * 1024, 10 * 1024 * 1024},
+		{"5", 10 * 1024 * 1024, 5 * 1024 * 1024},
+		{"0", 10 * 1024 * 1024, 10 * 1024 * 1024},
+		{"-5", 10 * 1024 * 1024, 10 * 1024 * 1024},
+		{"nope", 10 * 1024 * 1024, 10 * 1024 * 1024},
+	}
+
+	for _, testCase := range testCases {
+		if testCase.value == "" {
+			os.Unsetenv(envName)
+		} else {
+			os.Setenv(envName, testCase.value)
+		}
+		if b := envBytesOrDefault(envName, testCase.def); b != testCase.expected {
+			t.Errorf("envBytesOrDefault(%q, def=%v): got %v, want %v",
+				testCase.value, testCase.def, b, testCase.expected)
+		}
+	}
+}
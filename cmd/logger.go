@@ -17,12 +17,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/mc/pkg/console"
@@ -35,15 +39,25 @@ var trimStrings []string
 // Level type
 type Level int8
 
-// Enumerated level types
+// Enumerated level types, ordered from least to most severe so that a
+// sink's configured minimum Level can be compared with a plain >=.
 const (
-	Error Level = iota + 1
+	Debug Level = iota + 1
+	Info
+	Warn
+	Error
 	Fatal
 )
 
 func (level Level) String() string {
 	var lvlStr string
 	switch level {
+	case Debug:
+		lvlStr = "DEBUG"
+	case Info:
+		lvlStr = "INFO"
+	case Warn:
+		lvlStr = "WARN"
 	case Error:
 		lvlStr = "ERROR"
 	case Fatal:
@@ -52,23 +66,122 @@ func (level Level) String() string {
 	return lvlStr
 }
 
+// levelFromString parses the Level.String() form back into a Level,
+// used by sinks to compare an incoming logEntry against their minimum.
+// Entries always carry a level produced by logIf, so an unrecognized
+// string (which can't happen there) falls back to Error.
+func levelFromString(s string) Level {
+	level, ok := parseLevel(s)
+	if !ok {
+		return Error
+	}
+	return level
+}
+
+// parseLevel is the strict counterpart of levelFromString: ok is false
+// when s isn't one of the known Level.String() forms, letting callers
+// such as envLevelOrDefault distinguish "unrecognized" from a valid level.
+func parseLevel(s string) (level Level, ok bool) {
+	switch s {
+	case "DEBUG":
+		return Debug, true
+	case "INFO":
+		return Info, true
+	case "WARN":
+		return Warn, true
+	case "ERROR":
+		return Error, true
+	case "FATAL":
+		return Fatal, true
+	}
+	return 0, false
+}
+
 type logEntry struct {
-	Level   string   `json:"level"`
-	Message string   `json:"message"`
-	Time    string   `json:"time"`
-	Cause   string   `json:"cause"`
-	Trace   []string `json:"trace"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Time    string                 `json:"time"`
+	Cause   string                 `json:"cause"`
+	Trace   []string               `json:"trace"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
 }
 
-// Logger - for console messages
+// logEntryQueueSize bounds the number of logEntry records buffered between
+// the hot request-handling path and the sink worker goroutine, so a slow
+// sink (e.g. an unresponsive webhook) cannot block request handlers.
+const logEntryQueueSize = 10000
+
+// Logger - fans out console messages as well as structured logEntry
+// records. Console messages (Println/Printf) are written directly for
+// CLI-style output, while logEntry records produced by logIf are
+// dispatched asynchronously to a registered set of LogSink implementations.
 type Logger struct {
 	quiet bool
 	json  bool
+
+	mu      sync.RWMutex
+	sinks   []LogSink
+	entries chan logEntry
 }
 
 // NewLogger - to create a new Logger object
 func NewLogger() *Logger {
-	return &Logger{}
+	log := &Logger{
+		entries: make(chan logEntry, logEntryQueueSize),
+	}
+	log.AddSink(&consoleSink{log: log, level: Error})
+	go log.worker()
+	return log
+}
+
+// AddSink registers a LogSink to receive every logEntry at or above its
+// own minimum Level. Safe for concurrent use.
+func (log *Logger) AddSink(sink LogSink) {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.sinks = append(log.sinks, sink)
+}
+
+// worker drains queued logEntry records and fans them out to sinks. It
+// runs for the lifetime of the process.
+func (log *Logger) worker() {
+	for entry := range log.entries {
+		log.write(entry)
+	}
+}
+
+// write delivers entry to every registered sink whose minimum Level is
+// met, logging (to console) any sink error without recursing back into
+// logIf.
+func (log *Logger) write(entry logEntry) {
+	log.mu.RLock()
+	sinks := log.sinks
+	log.mu.RUnlock()
+
+	entryLevel := levelFromString(entry.Level)
+	for _, sink := range sinks {
+		if entryLevel < sink.Level() {
+			continue
+		}
+		if err := sink.Send(entry); err != nil {
+			console.Errorln("log sink error: " + err.Error())
+		}
+	}
+}
+
+// dispatch queues entry for asynchronous delivery to sinks, keeping the
+// caller's hot path allocation-light. Fatal entries are delivered
+// synchronously since the process exits immediately after.
+func (log *Logger) dispatch(entry logEntry) {
+	if levelFromString(entry.Level) == Fatal {
+		log.write(entry)
+		return
+	}
+	select {
+	case log.entries <- entry:
+	default:
+		// Sinks can't keep up; drop rather than block the request handler.
+	}
 }
 
 // EnableQuiet - turns quiet option on.
@@ -153,70 +266,150 @@ func getTrace(traceLevel int) []string {
 	return trace
 }
 
-func logIf(level Level, err error, msg string,
+func isErrIgnored(err error) (ok bool) {
+	err = errors.Cause(err)
+	switch err.(type) {
+	case BucketNotFound, BucketNotEmpty, BucketExists:
+		ok = true
+	case ObjectNotFound, ObjectExistsAsDirectory:
+		ok = true
+	case BucketPolicyNotFound, InvalidUploadID:
+		ok = true
+	}
+	return ok
+}
+
+// logIf builds and dispatches a logEntry carrying fields, the structured
+// context (request ID, API, bucket, ...) pulled out of a request's
+// context.Context by WithContext. errorIf/fatalIf/warnIf/infoIf/debugIf
+// all go through this via (*ContextLogger).logIf.
+func (cl *ContextLogger) logIf(level Level, err error, msg string,
 	data ...interface{}) {
 
-	isErrIgnored := func(err error) (ok bool) {
-		err = errors.Cause(err)
-		switch err.(type) {
-		case BucketNotFound, BucketNotEmpty, BucketExists:
-			ok = true
-		case ObjectNotFound, ObjectExistsAsDirectory:
-			ok = true
-		case BucketPolicyNotFound, InvalidUploadID:
-			ok = true
-		}
-		return ok
+	// errorIf/fatalIf always carry an error; an ignored error is simply
+	// not worth logging. warnIf/infoIf/debugIf may be called without one
+	// to report a plain message instead.
+	if err != nil && isErrIgnored(err) {
+		return
 	}
-
-	if err == nil || isErrIgnored(err) {
+	if err == nil && (level == Error || level == Fatal) {
 		return
 	}
-	cause := strings.Title(err.Error())
-	// Get full stack trace
-	trace := getTrace(3)
-	// Get time
+
+	var cause string
+	var trace []string
+	if err != nil {
+		cause = strings.Title(err.Error())
+		trace = getTrace(3)
+	}
 	timeOfError := UTCNow().Format(time.RFC3339Nano)
-	// Output the formatted log message at console
-	var output string
 	message := fmt.Sprintf(msg, data...)
-	if log.json {
-		logJSON, err := json.Marshal(&logEntry{
-			Level:   level.String(),
-			Message: message,
-			Time:    timeOfError,
-			Cause:   cause,
-			Trace:   trace,
-		})
+
+	entry := logEntry{
+		Level:   level.String(),
+		Message: message,
+		Time:    timeOfError,
+		Cause:   cause,
+		Trace:   trace,
+		Fields:  cl.fields,
+	}
+
+	cl.logger.dispatch(entry)
+	if level == Fatal {
+		os.Exit(1)
+	}
+}
+
+func errorIf(ctx context.Context, err error, msg string, data ...interface{}) {
+	log.WithContext(ctx).logIf(Error, err, msg, data...)
+}
+
+func fatalIf(ctx context.Context, err error, msg string, data ...interface{}) {
+	log.WithContext(ctx).logIf(Fatal, err, msg, data...)
+}
+
+func warnIf(ctx context.Context, err error, msg string, data ...interface{}) {
+	log.WithContext(ctx).logIf(Warn, err, msg, data...)
+}
+
+func infoIf(ctx context.Context, err error, msg string, data ...interface{}) {
+	log.WithContext(ctx).logIf(Info, err, msg, data...)
+}
+
+func debugIf(ctx context.Context, err error, msg string, data ...interface{}) {
+	log.WithContext(ctx).logIf(Debug, err, msg, data...)
+}
+
+// consoleSink is the always-present default LogSink, printing each
+// dispatched logEntry at or above its Level to stdout either as colorized
+// plain text or as JSON, depending on Logger.EnableJSON. NewLogger
+// registers it at Error, so Warn, Info and Debug entries are not printed.
+type consoleSink struct {
+	log   *Logger
+	level Level
+}
+
+func (c *consoleSink) Level() Level {
+	return c.level
+}
+
+func (c *consoleSink) Send(entry logEntry) error {
+	var output string
+	if c.log.json {
+		logJSON, err := json.Marshal(&entry)
 		if err != nil {
-			panic("json marshal of logEntry failed: " + err.Error())
+			return err
 		}
 		output = string(logJSON)
 	} else {
-		// Add a sequence number and formatting for each stack trace
-		// No formatting is required for the first entry
-		trace[0] = "1: " + trace[0]
-		for i, element := range trace[1:] {
-			trace[i+1] = fmt.Sprintf("%8v: %s", i+2, element)
-		}
-		errMsg := fmt.Sprintf("[%s] [%s] %s (%s)",
-			timeOfError, level.String(), message, cause)
-
-		output = fmt.Sprintf("\nTrace: %s\n%s",
-			strings.Join(trace, "\n"),
-			colorRed(colorBold(errMsg)))
+		output = formatPlainLogEntry(entry)
 	}
 	fmt.Println(output)
+	return nil
+}
 
-	if level == Fatal {
-		os.Exit(1)
+// formatPlainLogEntry renders a logEntry the same way the console has
+// always rendered it: its stack trace (if any), followed by a colorized
+// summary line with its structured Fields (if any) appended as grep-able
+// "key=value" pairs.
+func formatPlainLogEntry(entry logEntry) string {
+	// Number a copy, so the entry's Trace seen by other sinks is unchanged.
+	trace := append([]string(nil), entry.Trace...)
+	if len(trace) == 0 {
+		errMsg := fmt.Sprintf("[%s] [%s] %s%s",
+			entry.Time, entry.Level, entry.Message, formatFields(entry.Fields))
+		return colorRed(colorBold(errMsg))
 	}
-}
 
-func errorIf(err error, msg string, data ...interface{}) {
-	logIf(Error, err, msg, data...)
+	// Add a sequence number and formatting for each stack trace
+	// No formatting is required for the first entry
+	trace[0] = "1: " + trace[0]
+	for i, element := range trace[1:] {
+		trace[i+1] = fmt.Sprintf("%8v: %s", i+2, element)
+	}
+	errMsg := fmt.Sprintf("[%s] [%s] %s%s (%s)",
+		entry.Time, entry.Level, entry.Message, formatFields(entry.Fields), entry.Cause)
+
+	return fmt.Sprintf("\nTrace: %s\n%s",
+		strings.Join(trace, "\n"),
+		colorRed(colorBold(errMsg)))
 }
 
-func fatalIf(err error, msg string, data ...interface{}) {
-	logIf(Fatal, err, msg, data...)
+// formatFields renders fields as a leading-space-separated " key=value"
+// string, sorted by key for stable output, or "" when fields is empty.
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	return b.String()
 }
@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestLevelFromString(t *testing.T) {
+	testCases := []struct {
+		s        string
+		expected Level
+	}{
+		{"DEBUG", Debug},
+		{"INFO", Info},
+		{"WARN", Warn},
+		{"ERROR", Error},
+		{"FATAL", Fatal},
+		{"BOGUS", Error},
+		{"", Error},
+	}
+	for _, testCase := range testCases {
+		if level := levelFromString(testCase.s); level != testCase.expected {
+			t.Errorf("levelFromString(%q): got %v, want %v", testCase.s, level, testCase.expected)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		s          string
+		expected   Level
+		expectedOk bool
+	}{
+		{"DEBUG", Debug, true},
+		{"INFO", Info, true},
+		{"WARN", Warn, true},
+		{"ERROR", Error, true},
+		{"FATAL", Fatal, true},
+		{"BOGUS", 0, false},
+		{"", 0, false},
+	}
+	for _, testCase := range testCases {
+		level, ok := parseLevel(testCase.s)
+		if ok != testCase.expectedOk || (ok && level != testCase.expected) {
+			t.Errorf("parseLevel(%q): got (%v, %v), want (%v, %v)",
+				testCase.s, level, ok, testCase.expected, testCase.expectedOk)
+		}
+	}
+}
+
+func TestFormatFields(t *testing.T) {
+	if s := formatFields(nil); s != "" {
+		t.Errorf("formatFields(nil): got %q, want empty string", s)
+	}
+	if s := formatFields(map[string]interface{}{}); s != "" {
+		t.Errorf("formatFields(empty map): got %q, want empty string", s)
+	}
+
+	fields := map[string]interface{}{
+		"bucket": "mybucket",
+		"api":    "GetObject",
+	}
+	expected := " api=GetObject bucket=mybucket"
+	if s := formatFields(fields); s != expected {
+		t.Errorf("formatFields: got %q, want %q (keys must be sorted)", s, expected)
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	testCases := []struct {
+		level    Level
+		expected string
+	}{
+		{Debug, "DEBUG"},
+		{Info, "INFO"},
+		{Warn, "WARN"},
+		{Error, "ERROR"},
+		{Fatal, "FATAL"},
+	}
+	for _, testCase := range testCases {
+		if s := testCase.level.String(); s != testCase.expected {
+			t.Errorf("Level(%d).String(): got %q, want %q", testCase.level, s, testCase.expected)
+		}
+	}
+}